@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/xeptore/sud/pkg/sud"
+)
+
+// maxConcurrentDownloads bounds how many jobs run at once, so a large
+// --flavors/--out fan-out doesn't open unbounded connections to GitHub.
+const maxConcurrentDownloads = 4
+
+// httpClientTimeout is the per-request timeout used by the *http.Client
+// shared across every concurrent download job.
+const httpClientTimeout = 30 * time.Second
+
+// downloadResult is a single job's outcome, kept for the summary table.
+type downloadResult struct {
+	Job     downloadJob
+	Release *sud.Release
+	Err     error
+}
+
+func runDownload(arg Args) {
+	jobs, err := buildJobs(arg.Outs, arg.Flavors)
+	if err != nil {
+		logErrorFatal(err.Error())
+	}
+	if len(jobs) > 1 && (len(arg.Version) > 0 || len(arg.SHA256) > 0) {
+		logErrorFatal("--version and --sha256 pin a single release and are only valid with one flavor and one --out")
+	}
+
+	warn(fmt.Sprintf("running %d download job(s)...", len(jobs)))
+	httpClient := &http.Client{Timeout: httpClientTimeout}
+	results := runJobs(context.Background(), jobs, arg, httpClient)
+	printSummary(results)
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, sud.ErrNoUpdateAvailable) {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", result.Job.Flavor, result.Job.OutDir, result.Err))
+		}
+	}
+	if joined := errors.Join(errs...); joined != nil {
+		logErrorFatal(joined.Error())
+	}
+
+	goodLuck("Have a nice day :)")
+}
+
+// runJobs runs jobs concurrently, bounded by maxConcurrentDownloads, and
+// returns their results in the same order jobs were given. Every job's
+// Source shares httpClient, so the whole pool uses one connection pool to
+// GitHub instead of one per job.
+func runJobs(ctx context.Context, jobs []downloadJob, arg Args, httpClient *http.Client) []downloadResult {
+	results := make([]downloadResult, len(jobs))
+	sem := make(chan struct{}, maxConcurrentDownloads)
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		i, job := i, job
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = runJob(ctx, job, arg, httpClient)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func runJob(ctx context.Context, job downloadJob, arg Args, httpClient *http.Client) downloadResult {
+	outputPath, err := getAbsoluteOutputPath(job.OutDir)
+	if err != nil {
+		return downloadResult{Job: job, Err: fmt.Errorf("resolving output directory: %w", err)}
+	}
+
+	downloader := sud.NewDownloader(sud.NewGitHubReleasesSourceWithClient(job.Owner, job.Repo, httpClient))
+	release, err := downloader.Download(ctx, outputPath, sud.DownloadOptions{
+		Version:            arg.Version,
+		Verify:             arg.Verify,
+		ExpectedSHA256:     arg.SHA256,
+		IncludePrereleases: arg.IncludePrereleases,
+	})
+	return downloadResult{Job: job, Release: release, Err: err}
+}
+
+func printSummary(results []downloadResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FLAVOR\tOUTPUT\tVERSION\tSTATUS")
+	for _, result := range results {
+		version := "-"
+		status := "ok"
+		switch {
+		case errors.Is(result.Err, sud.ErrNoUpdateAvailable):
+			status = "up to date"
+		case result.Err != nil:
+			status = fmt.Sprintf("error: %v", result.Err)
+		default:
+			version = result.Release.TagName
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Job.Flavor, result.Job.OutDir, version, status)
+	}
+	w.Flush()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"path"
+)
+
+// flavorSpec maps a UI flavor name to the GitHub repository sud downloads
+// its releases from.
+type flavorSpec struct {
+	Owner string
+	Repo  string
+}
+
+var knownFlavors = map[string]flavorSpec{
+	"swagger-ui": {Owner: "swagger-api", Repo: "swagger-ui"},
+	"redoc":      {Owner: "Redocly", Repo: "redoc"},
+	"rapidoc":    {Owner: "rapi-doc", Repo: "RapiDoc"},
+}
+
+// downloadJob is a single (flavor, output directory) pair to run through the
+// worker pool.
+type downloadJob struct {
+	Flavor string
+	Owner  string
+	Repo   string
+	OutDir string
+}
+
+// buildJobs expands arg's --out targets and --flavors list into the jobs to
+// run. Exactly one of the two may have more than one entry: a single --out
+// with multiple flavors fans out into per-flavor subdirectories, a single
+// flavor with multiple --out targets installs the same flavor everywhere,
+// and matching counts are paired up index by index.
+func buildJobs(outs, flavors []string) ([]downloadJob, error) {
+	switch {
+	case len(outs) == 1:
+		jobs := make([]downloadJob, 0, len(flavors))
+		for _, name := range flavors {
+			spec, ok := knownFlavors[name]
+			if !ok {
+				return nil, fmt.Errorf("sud: unknown flavor %q", name)
+			}
+			outDir := outs[0]
+			if len(flavors) > 1 {
+				outDir = path.Join(outs[0], name)
+			}
+			jobs = append(jobs, downloadJob{Flavor: name, Owner: spec.Owner, Repo: spec.Repo, OutDir: outDir})
+		}
+		return jobs, nil
+
+	case len(flavors) == 1:
+		spec, ok := knownFlavors[flavors[0]]
+		if !ok {
+			return nil, fmt.Errorf("sud: unknown flavor %q", flavors[0])
+		}
+		jobs := make([]downloadJob, 0, len(outs))
+		for _, outDir := range outs {
+			jobs = append(jobs, downloadJob{Flavor: flavors[0], Owner: spec.Owner, Repo: spec.Repo, OutDir: outDir})
+		}
+		return jobs, nil
+
+	case len(outs) == len(flavors):
+		jobs := make([]downloadJob, 0, len(outs))
+		for i, name := range flavors {
+			spec, ok := knownFlavors[name]
+			if !ok {
+				return nil, fmt.Errorf("sud: unknown flavor %q", name)
+			}
+			jobs = append(jobs, downloadJob{Flavor: name, Owner: spec.Owner, Repo: spec.Repo, OutDir: outs[i]})
+		}
+		return jobs, nil
+
+	default:
+		return nil, fmt.Errorf("sud: --out (%d given) and --flavors (%d given) counts must match, or one of them must be singular", len(outs), len(flavors))
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+)
+
+const SudOwner = "xeptore"
+const SudRepo = "sud"
+
+// defaultFlavor is used when --flavors is not given.
+const defaultFlavor = "swagger-ui"
+
+// Version is the running binary's version, set at build time via
+// `-ldflags "-X main.Version=..."`. It is used by `sud self-update` to
+// decide whether an available release is actually newer.
+var Version = "dev"
+
+const Usage = `
+API Docs UI Downloader
+
+Usage:
+  sud [--out=<dir>...] [--flavors=<list>] [--version=<semver>] [--verify] [--sha256=<hex>] [--include-prereleases]
+  sud self-update [--output=<path>] [--check] [--force]
+
+Options:
+  -h --help  			Show help screen and exits
+  --out=<dir>  			Directory to store output to (relative); may be given multiple times [default: .]
+  --flavors=<list>		Comma-separated list of UI flavors to download: swagger-ui, redoc, rapidoc [default: swagger-ui]
+  --version=<semver>		Pin to a specific release instead of latest (only valid for a single flavor)
+  --verify			Verify the downloaded tarball against a checksum before extracting
+  --sha256=<hex>		Expected SHA-256 checksum of the downloaded tarball
+  --include-prereleases	Allow picking a pre-release as the latest version
+  --output=<path>		Path of the sud binary to replace [default: ]
+  --check			Report whether a newer sud release is available without installing it
+  --force			Install the release even if it is not newer than the running binary
+`
+
+type Args struct {
+	Outs               []string
+	Flavors            []string
+	Version            string
+	Verify             bool
+	SHA256             string
+	IncludePrereleases bool
+
+	SelfUpdate bool
+	Output     string
+	Check      bool
+	Force      bool
+}
+
+func getAbsoluteOutputPath(relativeOutputPath string) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(dir, relativeOutputPath), nil
+}
+
+func main() {
+	var arg Args
+	parseArgs(&arg)
+
+	if arg.SelfUpdate {
+		runSelfUpdate(arg)
+		return
+	}
+	runDownload(arg)
+}
+
+// stringList returns key's value as a slice regardless of whether docopt
+// parsed it as a single string (one occurrence) or a []string (repeated
+// occurrences of `--flag=<val>...`).
+func stringList(arguments docopt.Opts, key string) []string {
+	switch v := arguments[key].(type) {
+	case []string:
+		return v
+	case string:
+		if len(v) == 0 {
+			return nil
+		}
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+func parseArgs(args *Args) {
+	arguments, err := docopt.ParseDoc(Usage)
+	if err != nil {
+		log.Fatalf("error occurred in parsing arguments: %v\n", err)
+	}
+
+	args.Outs = stringList(arguments, "--out")
+	if len(args.Outs) == 0 {
+		args.Outs = []string{"."}
+	}
+
+	flavorsRaw, _ := arguments.String("--flavors")
+	args.Flavors = splitFlavors(flavorsRaw)
+	if len(args.Flavors) == 0 {
+		args.Flavors = []string{defaultFlavor}
+	}
+
+	args.Version, _ = arguments.String("--version")
+	args.Verify, _ = arguments.Bool("--verify")
+	args.SHA256, _ = arguments.String("--sha256")
+	args.IncludePrereleases, _ = arguments.Bool("--include-prereleases")
+
+	args.SelfUpdate, _ = arguments.Bool("self-update")
+	args.Output, _ = arguments.String("--output")
+	args.Check, _ = arguments.Bool("--check")
+	args.Force, _ = arguments.Bool("--force")
+}
+
+func splitFlavors(raw string) []string {
+	var flavors []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) > 0 {
+			flavors = append(flavors, name)
+		}
+	}
+	return flavors
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/xeptore/sud/pkg/sud"
+)
+
+// errAlreadyUpToDate is returned by doSelfUpdate when the running binary is
+// already at least as new as the latest release.
+var errAlreadyUpToDate = errors.New("sud: already up to date")
+
+func runSelfUpdate(arg Args) {
+	ctx := context.Background()
+	source := sud.NewGitHubReleasesSource(SudOwner, SudRepo)
+
+	if arg.Check {
+		release, err := source.FetchLatest(ctx)
+		if err != nil {
+			logErrorFatal(fmt.Sprintf("checking for sud updates: %v", err))
+		}
+		if sud.IsNewer(Version, release.TagName) {
+			warn(fmt.Sprintf("update available: %s -> %s", Version, release.TagName))
+		} else {
+			warn(fmt.Sprintf("already up to date (%s).", Version))
+		}
+		return
+	}
+
+	warn("checking for sud updates...")
+	release, err := doSelfUpdate(ctx, source, arg.Output, arg.Force)
+	if errors.Is(err, errAlreadyUpToDate) {
+		warn(fmt.Sprintf("already up to date (%s).", Version))
+		return
+	}
+	if err != nil {
+		logErrorFatal(fmt.Sprintf("self-update failed: %v", err))
+	}
+
+	warn(fmt.Sprintf("updated sud to %s.", release.TagName))
+	goodLuck("Have a nice day :)")
+}
+
+// doSelfUpdate downloads, verifies and installs the latest sud release over
+// the binary at outputPath (or the running executable, if outputPath is
+// empty).
+func doSelfUpdate(ctx context.Context, source *sud.GitHubReleasesSource, outputPath string, force bool) (*sud.Release, error) {
+	release, err := source.FetchLatest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching latest release: %w", err)
+	}
+
+	if !force && !sud.IsNewer(Version, release.TagName) {
+		return nil, errAlreadyUpToDate
+	}
+
+	assetName := selfUpdateAssetName()
+	asset, ok := findAsset(release.Assets, assetName)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no asset named %s", release.TagName, assetName)
+	}
+
+	expectedSHA256, err := sud.FindChecksumForAsset(ctx, source, release.Assets, assetName)
+	if err != nil {
+		return nil, fmt.Errorf("resolving checksum: %w", err)
+	}
+
+	target := outputPath
+	if len(target) == 0 {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("locating running executable: %w", err)
+		}
+		target = exe
+	}
+
+	// Stage the extracted binary next to target rather than in the OS temp
+	// dir, so the final os.Rename in replaceExecutable lands on the same
+	// filesystem instead of failing with EXDEV when /tmp is a separate mount.
+	extractDir, err := ioutil.TempDir(filepath.Dir(target), "sud-self-update-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp extraction dir: %w", err)
+	}
+	defer os.RemoveAll(extractDir)
+
+	actualSHA256, err := sud.DownloadAndExtract(ctx, source, asset.BrowserDownloadURL, extractDir, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(expectedSHA256, actualSHA256) {
+		return nil, &sud.ChecksumMismatchError{Expected: expectedSHA256, Actual: actualSHA256}
+	}
+
+	extractedBinary := filepath.Join(extractDir, binaryName())
+	if _, err := os.Stat(extractedBinary); err != nil {
+		return nil, fmt.Errorf("extracted release does not contain %s: %w", filepath.Base(extractedBinary), err)
+	}
+
+	if err := replaceExecutable(extractedBinary, target); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// replaceExecutable atomically installs newBinary over target. On every
+// platform (notably Windows, which refuses to overwrite a running
+// executable), target is first renamed aside to target+".old" and only
+// removed once the new binary is safely in place.
+func replaceExecutable(newBinary, target string) error {
+	mode := os.FileMode(0755)
+	if info, err := os.Stat(target); err == nil {
+		mode = info.Mode()
+	}
+	if err := os.Chmod(newBinary, mode); err != nil {
+		return fmt.Errorf("setting permissions on new binary: %w", err)
+	}
+
+	oldPath := target + ".old"
+	os.Remove(oldPath)
+
+	renamedOld := false
+	if _, err := os.Stat(target); err == nil {
+		if err := os.Rename(target, oldPath); err != nil {
+			return fmt.Errorf("renaming current executable out of the way: %w", err)
+		}
+		renamedOld = true
+	}
+
+	if err := os.Rename(newBinary, target); err != nil {
+		if renamedOld {
+			os.Rename(oldPath, target)
+		}
+		return fmt.Errorf("installing new executable: %w", err)
+	}
+
+	os.Remove(oldPath)
+	return nil
+}
+
+func selfUpdateAssetName() string {
+	return fmt.Sprintf("sud_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+}
+
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "sud.exe"
+	}
+	return "sud"
+}
+
+func findAsset(assets []sud.Asset, name string) (sud.Asset, bool) {
+	for _, asset := range assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return sud.Asset{}, false
+}
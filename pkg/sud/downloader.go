@@ -0,0 +1,276 @@
+package sud
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// tarballStripComponents strips the single `<owner>-<repo>-<sha>/` directory
+// GitHub's source tarballs wrap their contents in.
+const tarballStripComponents = 1
+
+// Downloader fetches and installs releases of a Source into a local
+// directory.
+type Downloader struct {
+	Source Source
+}
+
+// NewDownloader returns a Downloader backed by the given Source.
+func NewDownloader(source Source) *Downloader {
+	return &Downloader{Source: source}
+}
+
+// DownloadOptions customizes a single Downloader.Download call.
+type DownloadOptions struct {
+	// Version pins the download to v<Version> instead of the latest release.
+	Version string
+	// ExpectedSHA256, if set, is compared against the downloaded tarball's
+	// checksum; a mismatch fails the download.
+	ExpectedSHA256 string
+	// Verify requires a checksum to be resolved (from ExpectedSHA256 or the
+	// release's checksums asset) before extracting the tarball.
+	Verify bool
+	// IncludePrereleases allows the highest release picked when Version is
+	// unset to be a pre-release, instead of only ever considering the
+	// highest stable one.
+	IncludePrereleases bool
+}
+
+// LatestVersion returns the sanitized tag name of the source's highest
+// release, without downloading anything.
+func (d *Downloader) LatestVersion(ctx context.Context, includePrereleases bool) (string, error) {
+	release, err := SelectHighestRelease(ctx, d.Source, includePrereleases)
+	if err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+// SelectHighestRelease paginates through source's releases and returns the
+// one with the highest SemVer precedence, skipping pre-releases unless
+// includePrereleases is set and ignoring tags that aren't valid semver.
+func SelectHighestRelease(ctx context.Context, source Source, includePrereleases bool) (*Release, error) {
+	var best *Release
+	var bestVer Semver
+
+	for page := 1; ; page++ {
+		releases, err := source.ListReleases(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("sud: listing releases: %w", err)
+		}
+		if len(releases) == 0 {
+			break
+		}
+
+		for i := range releases {
+			tag := releases[i].TagName
+			sanitizeVersion(&tag)
+			version, err := ParseSemver(tag)
+			if err != nil {
+				continue
+			}
+			if version.IsPrerelease() && !includePrereleases {
+				continue
+			}
+			if best == nil || version.Compare(bestVer) > 0 {
+				release := releases[i]
+				release.TagName = tag
+				best = &release
+				bestVer = version
+			}
+		}
+
+		if len(releases) < releasesPerPage {
+			break
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("sud: no matching release found")
+	}
+	return best, nil
+}
+
+// CurrentVersion returns the version recorded in dir's version file.
+func (d *Downloader) CurrentVersion(dir string) (string, error) {
+	vFile, err := readVersionFile(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(vFile.Version) == 0 {
+		return "", ErrVersionFileMissing
+	}
+	return vFile.Version, nil
+}
+
+// Download resolves the release described by opts, downloads and extracts
+// it into outDir, and records it in outDir's version file. It returns
+// ErrNoUpdateAvailable if outDir already has the requested release.
+func (d *Downloader) Download(ctx context.Context, outDir string, opts DownloadOptions) (*Release, error) {
+	existing, _ := readVersionFile(outDir)
+
+	// A version pinned on a previous run stays in effect until the caller
+	// passes an explicit --version again; otherwise a plain re-run would
+	// silently upgrade past the pin.
+	if len(opts.Version) == 0 && len(existing.Pinned) > 0 {
+		opts.Version = existing.Pinned
+	}
+
+	pinRequested := len(opts.Version) > 0
+	if pinRequested {
+		sanitized := opts.Version
+		sanitizeVersion(&sanitized)
+		if existing.Pinned == sanitized {
+			return nil, ErrNoUpdateAvailable
+		}
+	}
+
+	var release *Release
+	var err error
+	if pinRequested {
+		release, err = d.Source.FetchTag(ctx, opts.Version)
+		if err != nil {
+			return nil, fmt.Errorf("sud: fetching release info: %w", err)
+		}
+		sanitizeVersion(&release.TagName)
+	} else {
+		release, err = SelectHighestRelease(ctx, d.Source, opts.IncludePrereleases)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !pinRequested && !IsNewer(existing.Version, release.TagName) {
+		return nil, ErrNoUpdateAvailable
+	}
+
+	expectedSHA256, err := d.resolveExpectedSHA256(ctx, opts, release)
+	if err != nil {
+		return nil, err
+	}
+
+	extractDir, err := ioutil.TempDir("", "sud-extract-*")
+	if err != nil {
+		return nil, fmt.Errorf("sud: creating temp extraction dir: %w", err)
+	}
+	defer os.RemoveAll(extractDir)
+
+	actualSHA256, err := DownloadAndExtract(ctx, d.Source, release.TarballURL, extractDir, tarballStripComponents)
+	if err != nil {
+		return nil, err
+	}
+	if len(expectedSHA256) > 0 && !strings.EqualFold(expectedSHA256, actualSHA256) {
+		return nil, &ChecksumMismatchError{Expected: expectedSHA256, Actual: actualSHA256}
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("sud: creating output directory: %w", err)
+	}
+	if err := copyContentsToOutput(extractDir, outDir); err != nil {
+		return nil, fmt.Errorf("sud: copying files to output directory: %w", err)
+	}
+
+	pinned := ""
+	if pinRequested {
+		pinned = release.TagName
+	}
+	if err := writeVersionFile(outDir, release.TagName, pinned); err != nil {
+		return nil, fmt.Errorf("sud: saving version file: %w", err)
+	}
+
+	return release, nil
+}
+
+// DownloadAndExtract streams the artifact at url from source straight into
+// ExtractTarGz via a pipe, so it never touches disk as a whole file, and
+// returns its hex-encoded SHA-256 digest.
+func DownloadAndExtract(ctx context.Context, source Source, url, destDir string, stripComponents int) (string, error) {
+	pr, pw := io.Pipe()
+
+	var actualSHA256 string
+	var downloadErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer pw.Close()
+		actualSHA256, downloadErr = source.DownloadArtifact(ctx, url, pw)
+	}()
+
+	extractErr := ExtractTarGz(pr, destDir, stripComponents)
+	if extractErr != nil {
+		pr.CloseWithError(extractErr)
+	} else {
+		pr.Close()
+	}
+	<-done
+
+	if downloadErr != nil {
+		return "", downloadErr
+	}
+	if extractErr != nil {
+		return "", fmt.Errorf("sud: extracting tarball: %w", extractErr)
+	}
+	return actualSHA256, nil
+}
+
+func (d *Downloader) resolveExpectedSHA256(ctx context.Context, opts DownloadOptions, release *Release) (string, error) {
+	if len(opts.ExpectedSHA256) > 0 {
+		return strings.ToLower(opts.ExpectedSHA256), nil
+	}
+	if !opts.Verify {
+		return "", nil
+	}
+
+	checksum, err := FindChecksumForAsset(ctx, d.Source, release.Assets, tarballAssetName(release.TagName))
+	if err != nil {
+		return "", fmt.Errorf("sud: verification requested but checksum could not be resolved: %w", err)
+	}
+	return checksum, nil
+}
+
+// FindChecksumForAsset downloads the first checksums-style asset among
+// assets (one whose name contains "checksum" or "sha256") via source and
+// returns the hex digest it lists for assetName.
+func FindChecksumForAsset(ctx context.Context, source Source, assets []Asset, assetName string) (string, error) {
+	for _, asset := range assets {
+		name := strings.ToLower(asset.Name)
+		if !strings.Contains(name, "checksum") && !strings.Contains(name, "sha256") {
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := source.DownloadArtifact(ctx, asset.BrowserDownloadURL, &buf); err != nil {
+			return "", fmt.Errorf("sud: downloading checksums asset: %w", err)
+		}
+		return parseChecksumFromFile(buf.Bytes(), assetName)
+	}
+	return "", fmt.Errorf("sud: release does not publish a checksums asset")
+}
+
+func readVersionFile(dir string) (VersionFile, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, SavingFileName))
+	if err != nil {
+		return VersionFile{}, err
+	}
+
+	var vFile VersionFile
+	if err := yaml.Unmarshal(data, &vFile); err != nil {
+		return VersionFile{}, fmt.Errorf("sud: parsing version file: %w", err)
+	}
+	return vFile, nil
+}
+
+func writeVersionFile(dir, version, pinned string) error {
+	out, err := yaml.Marshal(VersionFile{Version: version, Pinned: pinned})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, SavingFileName), out, 0644)
+}
@@ -0,0 +1,126 @@
+package sud
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	copier "github.com/otiai10/copy"
+)
+
+// safeExtractUmask is applied to every extracted entry's mode on top of
+// whatever the tarball declares, so a maliciously crafted archive can't hand
+// out world-writable files or directories.
+const safeExtractUmask = 0022
+
+// ExtractTarGz reads a gzip-compressed tar stream from r and extracts it
+// into destDir. stripComponents leading path segments are dropped from every
+// entry name (mirroring `tar --strip-components`), and any entry whose
+// cleaned, stripped path would land outside destDir is rejected outright —
+// this is the explicit equivalent of GODEBUG=tarinsecurepath=0. Symlinks,
+// devices, fifos and other non-regular, non-directory entries are skipped
+// rather than honored, which also forecloses symlink-escape attacks.
+func ExtractTarGz(r io.Reader, destDir string, stripComponents int) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("sud: reading gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("sud: reading tar entry: %w", err)
+		}
+
+		if header.Name == "pax_global_header" {
+			continue
+		}
+
+		relPath, ok := stripPathComponents(header.Name, stripComponents)
+		if !ok || relPath == "" || relPath == "." {
+			continue
+		}
+
+		destPath, err := safeJoin(destDir, relPath)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(destPath, safeMode(header.FileInfo().Mode().Perm(), 0755)); err != nil {
+				return fmt.Errorf("sud: creating directory %q: %w", relPath, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+				return fmt.Errorf("sud: creating parent directory for %q: %w", relPath, err)
+			}
+			if err := writeExtractedFile(destPath, tr, safeMode(header.FileInfo().Mode().Perm(), 0644)); err != nil {
+				return fmt.Errorf("sud: writing file %q: %w", relPath, err)
+			}
+		default:
+			continue
+		}
+	}
+}
+
+func writeExtractedFile(destPath string, r io.Reader, mode os.FileMode) error {
+	out, err := os.OpenFile(destPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, r)
+	return err
+}
+
+func safeMode(mode, fallback os.FileMode) os.FileMode {
+	if mode == 0 {
+		mode = fallback
+	}
+	return mode &^ safeExtractUmask
+}
+
+// stripPathComponents drops the first n slash-separated components of name
+// and returns the cleaned remainder. It rejects absolute paths and reports
+// ok=false when name has fewer than n components.
+func stripPathComponents(name string, n int) (relPath string, ok bool) {
+	cleaned := filepath.ToSlash(filepath.Clean(name))
+	if filepath.IsAbs(cleaned) {
+		return "", false
+	}
+
+	parts := strings.Split(cleaned, "/")
+	if n >= len(parts) {
+		return "", false
+	}
+	return filepath.Join(parts[n:]...), true
+}
+
+// safeJoin joins destDir and relPath and verifies the result still lives
+// under destDir, rejecting any "../" escape that survived cleaning.
+func safeJoin(destDir, relPath string) (string, error) {
+	destPath := filepath.Join(destDir, relPath)
+	destDirWithSep := filepath.Clean(destDir) + string(os.PathSeparator)
+	if destPath != filepath.Clean(destDir) && !strings.HasPrefix(destPath, destDirWithSep) {
+		return "", fmt.Errorf("sud: tar entry %q escapes destination directory", relPath)
+	}
+	return destPath, nil
+}
+
+// copyContentsToOutput copies the extracted release's dist/ directory into
+// outPath. Because extraction already strips the tarball's top-level
+// `<owner>-<repo>-<sha>/` prefix, dist/ sits directly under extractDir.
+func copyContentsToOutput(extractDir, outPath string) error {
+	return copier.Copy(filepath.Join(extractDir, "dist"), outPath)
+}
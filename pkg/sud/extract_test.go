@@ -0,0 +1,105 @@
+package sud
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildTarGz packs entries into a gzip-compressed tar stream.
+func buildTarGz(t *testing.T, entries []tar.Header) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for i := range entries {
+		header := entries[i]
+		if err := tw.WriteHeader(&header); err != nil {
+			t.Fatalf("writing tar header for %q: %v", header.Name, err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte("content")); err != nil {
+				t.Fatalf("writing tar content for %q: %v", header.Name, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractTarGzRejectsPathTraversal(t *testing.T) {
+	destDir := t.TempDir()
+	archive := buildTarGz(t, []tar.Header{
+		{Name: "../evil.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 7},
+	})
+
+	err := ExtractTarGz(bytes.NewReader(archive), destDir, 0)
+	if err == nil {
+		t.Fatal("expected an error for a path-traversal entry, got nil")
+	}
+
+	if _, statErr := os.Stat(filepath.Join(filepath.Dir(destDir), "evil.txt")); statErr == nil {
+		t.Fatal("traversal entry was written outside destDir")
+	}
+}
+
+func TestExtractTarGzRejectsNestedPathTraversal(t *testing.T) {
+	destDir := t.TempDir()
+	archive := buildTarGz(t, []tar.Header{
+		{Name: "pkg/../../evil.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 7},
+	})
+
+	err := ExtractTarGz(bytes.NewReader(archive), destDir, 0)
+	if err == nil {
+		t.Fatal("expected an error for a nested path-traversal entry, got nil")
+	}
+}
+
+func TestExtractTarGzSkipsSymlinks(t *testing.T) {
+	destDir := t.TempDir()
+	archive := buildTarGz(t, []tar.Header{
+		{Name: "escape", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd", Mode: 0777},
+		{Name: "regular.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 7},
+	})
+
+	if err := ExtractTarGz(bytes.NewReader(archive), destDir, 0); err != nil {
+		t.Fatalf("ExtractTarGz: %v", err)
+	}
+
+	if _, err := os.Lstat(filepath.Join(destDir, "escape")); err == nil {
+		t.Fatal("symlink entry was extracted instead of being skipped")
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "regular.txt")); err != nil {
+		t.Fatalf("regular file entry was not extracted: %v", err)
+	}
+}
+
+func TestExtractTarGzStripsComponentsAndSkipsPaxGlobalHeader(t *testing.T) {
+	destDir := t.TempDir()
+	archive := buildTarGz(t, []tar.Header{
+		{Name: "pax_global_header", Typeflag: tar.TypeReg, Mode: 0644, Size: 7},
+		{Name: "sud-swagger-ui-abc123/dist/index.html", Typeflag: tar.TypeReg, Mode: 0644, Size: 7},
+	})
+
+	if err := ExtractTarGz(bytes.NewReader(archive), destDir, 1); err != nil {
+		t.Fatalf("ExtractTarGz: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(destDir, "dist", "index.html")); err != nil {
+		t.Fatalf("expected stripped entry to be extracted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "pax_global_header")); err == nil {
+		t.Fatal("pax_global_header entry should have been skipped")
+	}
+}
@@ -0,0 +1,138 @@
+package sud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a parsed SemVer 2.0.0 version, supporting pre-release and build
+// metadata (e.g. "5.0.0-rc.1+build.3").
+type Semver struct {
+	Major, Minor, Patch uint64
+	Prerelease          []string
+	Build               []string
+}
+
+// ParseSemver parses a (possibly "v"-prefixed) SemVer 2.0.0 version string.
+func ParseSemver(version string) (Semver, error) {
+	sanitizeVersion(&version)
+
+	core := version
+	var build string
+	if idx := strings.IndexByte(core, '+'); idx >= 0 {
+		core, build = core[:idx], core[idx+1:]
+	}
+
+	var prerelease string
+	if idx := strings.IndexByte(core, '-'); idx >= 0 {
+		core, prerelease = core[:idx], core[idx+1:]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("sud: %q is not a valid semver core version", version)
+	}
+
+	major, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return Semver{}, fmt.Errorf("sud: invalid major version in %q: %w", version, err)
+	}
+	minor, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return Semver{}, fmt.Errorf("sud: invalid minor version in %q: %w", version, err)
+	}
+	patch, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return Semver{}, fmt.Errorf("sud: invalid patch version in %q: %w", version, err)
+	}
+
+	sv := Semver{Major: major, Minor: minor, Patch: patch}
+	if len(prerelease) > 0 {
+		sv.Prerelease = strings.Split(prerelease, ".")
+	}
+	if len(build) > 0 {
+		sv.Build = strings.Split(build, ".")
+	}
+	return sv, nil
+}
+
+// IsPrerelease reports whether v has a pre-release component.
+func (v Semver) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 as v is lower than, equal to, or higher than
+// other, following SemVer 2.0.0 precedence rules. Build metadata is ignored,
+// per spec.
+func (v Semver) Compare(other Semver) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease implements SemVer 2.0.0 rule 11: a version without a
+// pre-release has higher precedence than one with; otherwise identifiers
+// are compared left to right, numeric identifiers are lower than
+// alphanumeric ones, and a version with more identifiers (all preceding
+// ones equal) has higher precedence.
+func comparePrerelease(a, b []string) int {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	if len(a) == 0 {
+		return 1
+	}
+	if len(b) == 0 {
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func compareIdentifier(a, b string) int {
+	aNum, aIsNum := parseUintIdentifier(a)
+	bNum, bIsNum := parseUintIdentifier(b)
+
+	switch {
+	case aIsNum && bIsNum:
+		return compareUint(aNum, bNum)
+	case aIsNum && !bIsNum:
+		return -1
+	case !aIsNum && bIsNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func parseUintIdentifier(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,122 @@
+package sud
+
+import "testing"
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Semver
+		wantErr bool
+	}{
+		{in: "1.2.3", want: Semver{Major: 1, Minor: 2, Patch: 3}},
+		{in: "v1.2.3", want: Semver{Major: 1, Minor: 2, Patch: 3}},
+		{
+			in:   "1.2.3-rc.1+build.5",
+			want: Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}, Build: []string{"build", "5"}},
+		},
+		{in: "not-a-version", wantErr: true},
+		{in: "1.2", wantErr: true},
+		{in: "1.2.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSemver(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSemver(%q): expected an error, got %+v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSemver(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got.Major != tt.want.Major || got.Minor != tt.want.Minor || got.Patch != tt.want.Patch {
+			t.Errorf("ParseSemver(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// Each row is ordered lowest to highest precedence, mirroring the
+	// SemVer 2.0.0 spec's own example chain.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a, err := ParseSemver(ordered[i])
+			if err != nil {
+				t.Fatalf("ParseSemver(%q): %v", ordered[i], err)
+			}
+			b, err := ParseSemver(ordered[j])
+			if err != nil {
+				t.Fatalf("ParseSemver(%q): %v", ordered[j], err)
+			}
+
+			want := compareUint(uint64(i), uint64(j))
+			if got := a.Compare(b); got != want {
+				t.Errorf("%q.Compare(%q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestSemverCompareIgnoresBuildMetadata(t *testing.T) {
+	a, err := ParseSemver("1.0.0+build.1")
+	if err != nil {
+		t.Fatalf("ParseSemver: %v", err)
+	}
+	b, err := ParseSemver("1.0.0+build.2")
+	if err != nil {
+		t.Fatalf("ParseSemver: %v", err)
+	}
+	if c := a.Compare(b); c != 0 {
+		t.Errorf("Compare with differing build metadata only = %d, want 0", c)
+	}
+}
+
+func TestSemverCompareNumericIdentifiersNotLexicographic(t *testing.T) {
+	// A naive string comparison would put "1.0.0-9" above "1.0.0-10".
+	nine, err := ParseSemver("1.0.0-9")
+	if err != nil {
+		t.Fatalf("ParseSemver: %v", err)
+	}
+	ten, err := ParseSemver("1.0.0-10")
+	if err != nil {
+		t.Fatalf("ParseSemver: %v", err)
+	}
+	if c := nine.Compare(ten); c != -1 {
+		t.Errorf("1.0.0-9.Compare(1.0.0-10) = %d, want -1", c)
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, candidate string
+		want               bool
+	}{
+		{current: "", candidate: "1.0.0", want: true},
+		{current: "1.0.0", candidate: "1.0.1", want: true},
+		{current: "1.0.1", candidate: "1.0.0", want: false},
+		{current: "1.0.0", candidate: "1.0.0", want: false},
+		{current: "1.0.0", candidate: "not-a-version", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.current, tt.candidate); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.current, tt.candidate, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package sud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// releasesPerPage is the page size used when paginating a Source's release
+// list, e.g. via ListReleases.
+const releasesPerPage = 100
+
+// defaultHTTPTimeout bounds every request a GitHubReleasesSource makes,
+// metadata calls and artifact downloads alike.
+const defaultHTTPTimeout = 30 * time.Second
+
+// userAgent is sent on every request; the GitHub API rejects requests with
+// no User-Agent header.
+const userAgent = "sud"
+
+// Source fetches release metadata and downloads release artifacts. It lets
+// callers plug in alternative backends (a GitLab instance, a local mirror, a
+// Go-module-style proxy) without forking the Downloader.
+type Source interface {
+	// FetchLatest returns the most recent release.
+	FetchLatest(ctx context.Context) (*Release, error)
+	// FetchTag returns the release tagged v<version>.
+	FetchTag(ctx context.Context, version string) (*Release, error)
+	// ListReleases returns one page (1-indexed) of the repository's releases,
+	// newest first, in pages of releasesPerPage. An empty slice with a nil
+	// error means page is past the end of the list.
+	ListReleases(ctx context.Context, page int) ([]Release, error)
+	// DownloadArtifact streams the artifact at url into dest and returns its
+	// hex-encoded SHA-256 digest.
+	DownloadArtifact(ctx context.Context, url string, dest io.Writer) (sha256Hex string, err error)
+}
+
+// githubRelease mirrors the subset of the GitHub releases API response that
+// GitHubReleasesSource cares about.
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	TarballURL string `json:"tarball_url"`
+	Assets     []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func (r *githubRelease) toRelease() *Release {
+	release := &Release{TagName: r.TagName, TarballURL: r.TarballURL}
+	for _, asset := range r.Assets {
+		release.Assets = append(release.Assets, Asset{Name: asset.Name, BrowserDownloadURL: asset.BrowserDownloadURL})
+	}
+	return release
+}
+
+// GitHubReleasesSource is the default Source, backed by a GitHub repository's
+// releases API.
+type GitHubReleasesSource struct {
+	Owner  string
+	Repo   string
+	Client *http.Client
+}
+
+// NewGitHubReleasesSource returns a Source for the given GitHub repository,
+// with its own *http.Client with sane request timeouts. Callers that build
+// several Sources concurrently (e.g. one per flavor) and want them to share
+// a connection pool should use NewGitHubReleasesSourceWithClient instead.
+func NewGitHubReleasesSource(owner, repo string) *GitHubReleasesSource {
+	return NewGitHubReleasesSourceWithClient(owner, repo, &http.Client{Timeout: defaultHTTPTimeout})
+}
+
+// NewGitHubReleasesSourceWithClient returns a Source for the given GitHub
+// repository that issues its requests through client, letting callers share
+// one *http.Client (and its connection pool) across several Sources.
+func NewGitHubReleasesSourceWithClient(owner, repo string, client *http.Client) *GitHubReleasesSource {
+	return &GitHubReleasesSource{Owner: owner, Repo: repo, Client: client}
+}
+
+func (s *GitHubReleasesSource) releaseURL(suffix string) string {
+	return fmt.Sprintf("https://api.github.com/repos/%s/%s/releases%s", s.Owner, s.Repo, suffix)
+}
+
+func (s *GitHubReleasesSource) do(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sud: building request for %s: %w", url, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sud: requesting %s: %w", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, fmt.Errorf("sud: %s returned status %s", url, res.Status)
+	}
+	return res, nil
+}
+
+func (s *GitHubReleasesSource) fetchRelease(ctx context.Context, url string) (*Release, error) {
+	res, err := s.do(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var ghRelease githubRelease
+	if err := json.NewDecoder(res.Body).Decode(&ghRelease); err != nil {
+		return nil, fmt.Errorf("sud: decoding release info: %w", err)
+	}
+	return ghRelease.toRelease(), nil
+}
+
+func (s *GitHubReleasesSource) FetchLatest(ctx context.Context) (*Release, error) {
+	return s.fetchRelease(ctx, s.releaseURL("/latest"))
+}
+
+func (s *GitHubReleasesSource) FetchTag(ctx context.Context, version string) (*Release, error) {
+	sanitizeVersion(&version)
+	return s.fetchRelease(ctx, s.releaseURL(fmt.Sprintf("/tags/v%s", version)))
+}
+
+func (s *GitHubReleasesSource) ListReleases(ctx context.Context, page int) ([]Release, error) {
+	url := s.releaseURL(fmt.Sprintf("?per_page=%d&page=%d", releasesPerPage, page))
+	res, err := s.do(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("sud: listing releases: %w", err)
+	}
+	defer res.Body.Close()
+
+	var ghReleases []githubRelease
+	if err := json.NewDecoder(res.Body).Decode(&ghReleases); err != nil {
+		return nil, fmt.Errorf("sud: decoding release list: %w", err)
+	}
+
+	releases := make([]Release, len(ghReleases))
+	for i := range ghReleases {
+		releases[i] = *ghReleases[i].toRelease()
+	}
+	return releases, nil
+}
+
+func (s *GitHubReleasesSource) DownloadArtifact(ctx context.Context, url string, dest io.Writer) (string, error) {
+	res, err := s.do(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("sud: downloading artifact: %w", err)
+	}
+	defer res.Body.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(dest, io.TeeReader(res.Body, hasher)); err != nil {
+		return "", fmt.Errorf("sud: writing artifact: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
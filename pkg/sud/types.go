@@ -0,0 +1,48 @@
+package sud
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SavingFileName is the name of the metadata file sud writes into an output
+// directory to remember which release is currently installed there.
+const SavingFileName = ".sud"
+
+// ErrNoUpdateAvailable is returned by Downloader.Download when the requested
+// release is already installed in the output directory.
+var ErrNoUpdateAvailable = errors.New("sud: no newer version available")
+
+// ErrVersionFileMissing is returned by Downloader.CurrentVersion when the
+// output directory has no (or an unreadable) version file.
+var ErrVersionFileMissing = errors.New("sud: version file missing")
+
+// ChecksumMismatchError is returned when a downloaded artifact's computed
+// SHA-256 does not match the checksum it was expected to have.
+type ChecksumMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("sud: checksum mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// Asset is a single downloadable file attached to a release.
+type Asset struct {
+	Name               string
+	BrowserDownloadURL string
+}
+
+// Release describes a single release as reported by a Source.
+type Release struct {
+	TagName    string
+	TarballURL string
+	Assets     []Asset
+}
+
+// VersionFile is the on-disk representation of SavingFileName.
+type VersionFile struct {
+	Version string
+	Pinned  string `yaml:"pinned,omitempty"`
+}
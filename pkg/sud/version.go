@@ -0,0 +1,44 @@
+package sud
+
+import (
+	"fmt"
+	"strings"
+)
+
+func sanitizeVersion(v *string) {
+	tempV := *v
+	if strings.Index(*v, "v") == 0 {
+		*v = tempV[1:]
+	}
+}
+
+// IsNewer reports whether candidate is a newer version than current,
+// following SemVer 2.0.0 precedence. An unparsable or empty current version
+// is always considered older; an unparsable candidate is never newer.
+func IsNewer(current, candidate string) bool {
+	currentVer, err := ParseSemver(current)
+	if err != nil {
+		return true
+	}
+	candidateVer, err := ParseSemver(candidate)
+	if err != nil {
+		return false
+	}
+	return currentVer.Compare(candidateVer) < 0
+}
+
+// tarballAssetName returns the conventional checksums-file entry name for
+// the source tarball of the given (already sanitized) release tag.
+func tarballAssetName(version string) string {
+	return fmt.Sprintf("swagger-ui-%s.tar.gz", version)
+}
+
+func parseChecksumFromFile(data []byte, assetName string) (string, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.Contains(fields[1], assetName) {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("sud: no checksum found for %s", assetName)
+}